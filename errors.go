@@ -0,0 +1,141 @@
+package trie
+
+import "fmt"
+
+// MissingNodeError is returned by TryGet, TryDelete and TryWalkPath when
+// resolution of a key stops at a node that is absent from the trie and
+// could not be supplied by a NodeResolver. Path holds the rune path, from
+// the root, at which resolution stopped.
+type MissingNodeError struct {
+	Path []rune
+}
+
+func (err *MissingNodeError) Error() string {
+	return fmt.Sprintf("trie: missing node at path %q", string(err.Path))
+}
+
+// NodeResolver supplies the subtree for a path that is absent from a
+// RuneTrie's Children map, e.g. by fetching it from a KV store or a remote
+// peer. A trie with a Resolver set fills in missing children as they are
+// looked up instead of treating them as permanently absent.
+type NodeResolver interface {
+	// Resolve returns the node found at path, rooted at whatever trie
+	// consulted the resolver. It returns an error if path cannot be
+	// resolved.
+	Resolve(path []rune) (*RuneTrie, error)
+}
+
+// resolve asks trie.Resolver for the node at path. The Resolver is consulted
+// whenever a child is absent from Children during TryGet, TryDelete and
+// TryWalkPath. It is nil by default, in which case resolve reports a
+// *MissingNodeError for path; Get/Delete/WalkPath are unaffected since they
+// do not consult it.
+func (trie *RuneTrie) resolve(path []rune) (*RuneTrie, error) {
+	if trie.Resolver == nil {
+		return nil, &MissingNodeError{Path: path}
+	}
+	return trie.Resolver.Resolve(path)
+}
+
+// resolveChild returns node.Children[r], resolving it via trie.Resolver (path
+// being rooted at trie) and attaching it to node first if it is absent. Once
+// resolved, the child is stored in Children like any other node, so a
+// subsequent lookup finds it directly and TryDelete's parent bookkeeping has
+// a real entry to remove.
+func (trie *RuneTrie) resolveChild(node *RuneTrie, r rune, path []rune) (*RuneTrie, error) {
+	if child := node.Children[r]; child != nil {
+		return child, nil
+	}
+	child, err := trie.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if node.Children == nil {
+		node.Children = map[rune]*RuneTrie{}
+	}
+	node.Children[r] = child
+	return child, nil
+}
+
+// TryGet returns the Value stored at the given key. Unlike Get, TryGet
+// reports a *MissingNodeError instead of silently returning nil when
+// resolution stops at an absent node that the trie's Resolver (if any)
+// cannot supply.
+func (trie *RuneTrie) TryGet(key string) (interface{}, error) {
+	node := trie
+	path := make([]rune, 0, len(key))
+	for _, r := range key {
+		path = append(path, r)
+		child, err := trie.resolveChild(node, r, path)
+		if err != nil {
+			return nil, err
+		}
+		node = child
+	}
+	return node.Value, nil
+}
+
+// TryDelete removes the Value associated with the given key, behaving like
+// Delete but reporting a *MissingNodeError instead of returning false when
+// resolution stops at an absent, unresolvable node.
+func (trie *RuneTrie) TryDelete(key string) error {
+	path := make([]nodeRune, len(key))
+	rpath := make([]rune, 0, len(key))
+	node := trie
+	for i, r := range key {
+		rpath = append(rpath, r)
+		path[i] = nodeRune{r: r, node: node}
+		child, err := trie.resolveChild(node, r, rpath)
+		if err != nil {
+			return err
+		}
+		node = child
+	}
+
+	node.Value = nil
+	if node.isLeaf() {
+		for i := len(key) - 1; i >= 0; i-- {
+			parent := path[i].node
+			r := path[i].r
+			delete(parent.Children, r)
+			if !parent.isLeaf() {
+				break
+			}
+			parent.Children = nil
+			if parent.Value != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// TryWalkPath iterates over each key/Value in the path in trie from the
+// root to the node at the given key, calling the given walker function for
+// each key/Value. It behaves like WalkPath but reports a *MissingNodeError
+// instead of stopping silently when resolution stops at an absent,
+// unresolvable node.
+func (trie *RuneTrie) TryWalkPath(key string, walker WalkFunc) error {
+	if trie.Value != nil {
+		if err := walker("", trie.Value); err != nil {
+			return err
+		}
+	}
+
+	node := trie
+	path := make([]rune, 0, len(key))
+	for i, r := range key {
+		path = append(path, r)
+		child, err := trie.resolveChild(node, r, path)
+		if err != nil {
+			return err
+		}
+		node = child
+		if node.Value != nil {
+			if err := walker(string(key[0:i+1]), node.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package trie
+
+import "testing"
+
+func TestTryGetNoResolver(t *testing.T) {
+	trie := buildTestTrie("a", "ab")
+
+	if got, err := trie.TryGet("ab"); err != nil || got != "ab" {
+		t.Fatalf("TryGet(%q) = (%v, %v), want (\"ab\", nil)", "ab", got, err)
+	}
+
+	_, err := trie.TryGet("z")
+	mnErr, ok := err.(*MissingNodeError)
+	if !ok {
+		t.Fatalf("TryGet(%q) error = %v (%T), want *MissingNodeError", "z", err, err)
+	}
+	if string(mnErr.Path) != "z" {
+		t.Errorf("MissingNodeError.Path = %q, want %q", string(mnErr.Path), "z")
+	}
+}
+
+// stubResolver resolves any path to a fresh node carrying the path itself
+// (as a string) as its Value, simulating an external store.
+type stubResolver struct {
+	calls int
+}
+
+func (r *stubResolver) Resolve(path []rune) (*RuneTrie, error) {
+	r.calls++
+	node := NewRuneTrie()
+	node.Value = string(path)
+	return node, nil
+}
+
+func TestTryGetResolverAttachesChild(t *testing.T) {
+	trie := NewRuneTrie()
+	resolver := &stubResolver{}
+	trie.Resolver = resolver
+
+	got, err := trie.TryGet("k")
+	if err != nil {
+		t.Fatalf("TryGet(%q) error = %v", "k", err)
+	}
+	if got != "k" {
+		t.Fatalf("TryGet(%q) = %v, want %q", "k", got, "k")
+	}
+	if resolver.calls != 1 {
+		t.Fatalf("resolver called %d times, want 1", resolver.calls)
+	}
+
+	// A second TryGet must hit the now-attached child rather than resolving
+	// again.
+	if _, err := trie.TryGet("k"); err != nil {
+		t.Fatalf("TryGet(%q) second call error = %v", "k", err)
+	}
+	if resolver.calls != 1 {
+		t.Fatalf("resolver called %d times after second TryGet, want 1 (child should be cached)", resolver.calls)
+	}
+	if trie.Children['k'] == nil {
+		t.Fatal("resolved child was not attached to Children")
+	}
+}
+
+func TestTryDeleteResolverAttachesAndDeletes(t *testing.T) {
+	trie := NewRuneTrie()
+	trie.Resolver = &stubResolver{}
+
+	if err := trie.TryDelete("k"); err != nil {
+		t.Fatalf("TryDelete(%q) error = %v", "k", err)
+	}
+
+	// The resolved, now-deleted node must no longer be attached to
+	// Children, so no leftover node stays reachable.
+	if trie.Children['k'] != nil {
+		t.Fatalf("Children[%q] = %v, want nil after delete of a leaf with no other children", "k", trie.Children['k'])
+	}
+}
+
+func TestTryWalkPath(t *testing.T) {
+	trie := buildTestTrie("a", "ab")
+
+	var keys []string
+	err := trie.TryWalkPath("ab", func(key string, value interface{}) error {
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TryWalkPath error = %v", err)
+	}
+	want := []string{"a", "ab"}
+	if len(keys) != len(want) {
+		t.Fatalf("TryWalkPath visited %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("TryWalkPath visited %v, want %v", keys, want)
+		}
+	}
+}
+
+func TestTryWalkPathMissingNode(t *testing.T) {
+	trie := buildTestTrie("a")
+
+	err := trie.TryWalkPath("az", func(key string, value interface{}) error {
+		return nil
+	})
+	if _, ok := err.(*MissingNodeError); !ok {
+		t.Fatalf("TryWalkPath error = %v (%T), want *MissingNodeError", err, err)
+	}
+}
@@ -0,0 +1,138 @@
+package trie
+
+import "sort"
+
+// RuneTrieIterator provides stateful, resumable traversal over a RuneTrie.
+// Unlike Walk, which drives a callback recursively, an iterator holds an
+// explicit stack of frames so traversal can be paused, resumed, and used in
+// a range-style loop.
+type RuneTrieIterator struct {
+	stack []iteratorFrame
+	path  []rune
+	err   error
+
+	// pending marks that the cursor already sits on a node (placed there by
+	// Seek) that the next call to Next must redeliver rather than advance
+	// past.
+	pending bool
+}
+
+// iteratorFrame is a node being visited along with an in-progress iterator
+// over its Children, so the traversal can resume where it left off.
+type iteratorFrame struct {
+	node     *RuneTrie
+	children []runeChild
+	index    int
+	visited  bool
+}
+
+type runeChild struct {
+	r     rune
+	child *RuneTrie
+}
+
+// NodeIterator returns a RuneTrieIterator whose first call to Next lands on
+// the first key greater than or equal to start. Pass "" to iterate the
+// entire trie from the root.
+func (trie *RuneTrie) NodeIterator(start string) RuneTrieIterator {
+	it := RuneTrieIterator{
+		stack: []iteratorFrame{trie.iteratorFrame()},
+	}
+	if start != "" {
+		it.Seek(start)
+	}
+	return it
+}
+
+func (trie *RuneTrie) iteratorFrame() iteratorFrame {
+	children := make([]runeChild, 0, len(trie.Children))
+	for r, child := range trie.Children {
+		children = append(children, runeChild{r: r, child: child})
+	}
+	sortRuneChildren(children)
+	return iteratorFrame{node: trie, children: children}
+}
+
+// Next advances the iterator to the next node in the trie, in pre-order, and
+// reports whether a node was found. Internal nodes (with no Value) are
+// visited but are not valid leaves for LeafKey.
+func (it *RuneTrieIterator) Next() bool {
+	if it.pending {
+		it.pending = false
+		return true
+	}
+	for len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if !top.visited {
+			top.visited = true
+			return true
+		}
+		if top.index < len(top.children) {
+			rc := top.children[top.index]
+			top.index++
+			it.path = append(it.path, rc.r)
+			it.stack = append(it.stack, rc.child.iteratorFrame())
+			continue
+		}
+		// exhausted this node's children, pop back up
+		it.stack = it.stack[:len(it.stack)-1]
+		if len(it.path) > 0 {
+			it.path = it.path[:len(it.path)-1]
+		}
+	}
+	return false
+}
+
+// Key returns the key of the current node, or "" if the iterator has not
+// been advanced or is positioned at the root.
+func (it *RuneTrieIterator) Key() string {
+	return string(it.path)
+}
+
+// Value returns the Value of the current node, or nil for internal nodes.
+func (it *RuneTrieIterator) Value() interface{} {
+	if len(it.stack) == 0 {
+		return nil
+	}
+	return it.stack[len(it.stack)-1].node.Value
+}
+
+// Path returns the rune path from the root to the current node, including
+// internal nodes.
+func (it *RuneTrieIterator) Path() []rune {
+	path := make([]rune, len(it.path))
+	copy(path, it.path)
+	return path
+}
+
+// LeafKey returns the key of the current node. It panics if the current
+// node does not carry a Value.
+func (it *RuneTrieIterator) LeafKey() string {
+	if it.Value() == nil {
+		panic("trie: LeafKey called on a non-value-bearing node")
+	}
+	return it.Key()
+}
+
+// Seek fast-forwards the iterator to the first key greater than or equal to
+// prefix, reporting whether such a key was found. The matched node becomes
+// the iterator's current position: Key/Value/Path read it immediately, and
+// the following call to Next redelivers it rather than skipping past it.
+func (it *RuneTrieIterator) Seek(prefix string) bool {
+	for it.Next() {
+		if it.Key() >= prefix {
+			it.pending = true
+			return true
+		}
+	}
+	return false
+}
+
+// Error returns the first error encountered during traversal, if any.
+func (it *RuneTrieIterator) Error() error {
+	return it.err
+}
+
+func sortRuneChildren(children []runeChild) {
+	sort.Slice(children, func(i, j int) bool { return children[i].r < children[j].r })
+}
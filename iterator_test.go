@@ -0,0 +1,79 @@
+package trie
+
+import "testing"
+
+func TestNodeIteratorFullWalk(t *testing.T) {
+	trie := buildTestTrie("a", "ab", "b")
+
+	var got []string
+	it := trie.NodeIterator("")
+	for it.Next() {
+		if it.Value() != nil {
+			got = append(got, it.LeafKey())
+		}
+	}
+
+	want := map[string]bool{"a": true, "ab": true, "b": true}
+	if len(got) != len(want) {
+		t.Fatalf("NodeIterator(\"\") visited %v, want keys %v", got, want)
+	}
+	for _, key := range got {
+		if !want[key] {
+			t.Errorf("NodeIterator(\"\") visited unexpected key %q", key)
+		}
+	}
+}
+
+func TestNodeIteratorSeekIncludesMatch(t *testing.T) {
+	trie := buildTestTrie("a", "ab", "ac", "b", "ba", "c")
+
+	var got []string
+	it := trie.NodeIterator("b")
+	for it.Next() {
+		if it.Value() != nil {
+			got = append(got, it.LeafKey())
+		}
+	}
+
+	want := []string{"b", "ba", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("NodeIterator(\"b\") = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("NodeIterator(\"b\") = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSeekReturnsMatchWithoutConsumingIt(t *testing.T) {
+	trie := buildTestTrie("a", "ba")
+
+	it := trie.NodeIterator("")
+	if !it.Seek("ba") {
+		t.Fatal("Seek(\"ba\") = false, want true")
+	}
+	if key := it.Key(); key != "ba" {
+		t.Fatalf("after Seek, Key() = %q, want %q", key, "ba")
+	}
+	if !it.Next() {
+		t.Fatal("Next() after Seek = false, want true")
+	}
+	if key := it.Key(); key != "ba" {
+		t.Fatalf("Next() after Seek = %q, want %q (the seeked-to node)", key, "ba")
+	}
+}
+
+func TestLeafKeyPanicsOnInternalNode(t *testing.T) {
+	trie := buildTestTrie("ab")
+
+	it := trie.NodeIterator("")
+	it.Next() // the root "", an internal node with no Value
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("LeafKey() on an internal node did not panic")
+		}
+	}()
+	it.LeafKey()
+}
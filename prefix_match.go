@@ -0,0 +1,129 @@
+package trie
+
+import "sort"
+
+// PrefixMatch returns the keys of all Values stored in the trie whose key
+// has the given prefix. The order of the returned keys is unspecified.
+func (trie *RuneTrie) PrefixMatch(prefix string) []string {
+	node := trie.nodeAtPrefix(prefix)
+	if node == nil {
+		return nil
+	}
+	var keys []string
+	node.walk(prefix, func(key string, value interface{}) error {
+		keys = append(keys, key)
+		return nil
+	})
+	return keys
+}
+
+// PrefixMatchValues returns the Values stored in the trie whose key has the
+// given prefix. The order of the returned Values is unspecified.
+func (trie *RuneTrie) PrefixMatchValues(prefix string) []interface{} {
+	node := trie.nodeAtPrefix(prefix)
+	if node == nil {
+		return nil
+	}
+	var values []interface{}
+	node.walk(prefix, func(key string, value interface{}) error {
+		values = append(values, value)
+		return nil
+	})
+	return values
+}
+
+// nodeAtPrefix descends the trie to the node representing prefix, returning
+// nil if no such node exists.
+func (trie *RuneTrie) nodeAtPrefix(prefix string) *RuneTrie {
+	node := trie
+	for _, r := range prefix {
+		node = node.Children[r]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// ClosestKeys returns the keys stored in the trie within edit (Levenshtein)
+// distance max of query, sorted by increasing distance and then
+// lexicographically. It is suitable for "did you mean" style suggestions.
+func (trie *RuneTrie) ClosestKeys(query string, max int) []string {
+	queryRunes := []rune(query)
+	row := make([]int, len(queryRunes)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	var matches []fuzzyMatch
+	if trie.Value != nil && row[len(row)-1] <= max {
+		matches = append(matches, fuzzyMatch{key: "", distance: row[len(row)-1]})
+	}
+	for r, child := range trie.Children {
+		child.closestKeys(r, string(r), queryRunes, row, max, &matches)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].key < matches[j].key
+	})
+
+	keys := make([]string, len(matches))
+	for i, m := range matches {
+		keys[i] = m.key
+	}
+	return keys
+}
+
+type fuzzyMatch struct {
+	key      string
+	distance int
+}
+
+// closestKeys extends the dynamic-programming row of edit distances by one
+// column for the rune r, pruning the branch if no cell in the new row can
+// still fall within max.
+func (trie *RuneTrie) closestKeys(r rune, key string, query []rune, prevRow []int, max int, matches *[]fuzzyMatch) {
+	row := make([]int, len(prevRow))
+	row[0] = prevRow[0] + 1
+	for i := 1; i < len(row); i++ {
+		insertCost := row[i-1] + 1
+		deleteCost := prevRow[i] + 1
+		substituteCost := prevRow[i-1]
+		if query[i-1] != r {
+			substituteCost++
+		}
+		row[i] = min3(insertCost, deleteCost, substituteCost)
+	}
+
+	if trie.Value != nil && row[len(row)-1] <= max {
+		*matches = append(*matches, fuzzyMatch{key: key, distance: row[len(row)-1]})
+	}
+
+	rowMin := row[0]
+	for _, d := range row[1:] {
+		if d < rowMin {
+			rowMin = d
+		}
+	}
+	if rowMin > max {
+		return
+	}
+
+	for cr, child := range trie.Children {
+		child.closestKeys(cr, key+string(cr), query, row, max, matches)
+	}
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
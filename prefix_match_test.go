@@ -0,0 +1,64 @@
+package trie
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func buildTestTrie(keys ...string) *RuneTrie {
+	trie := NewRuneTrie()
+	for _, key := range keys {
+		trie.Put(key, key)
+	}
+	return trie
+}
+
+func TestPrefixMatch(t *testing.T) {
+	trie := buildTestTrie("a", "ab", "abc", "abd", "b")
+
+	got := trie.PrefixMatch("ab")
+	sort.Strings(got)
+	want := []string{"ab", "abc", "abd"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PrefixMatch(%q) = %v, want %v", "ab", got, want)
+	}
+
+	if got := trie.PrefixMatch("z"); got != nil {
+		t.Errorf("PrefixMatch(%q) = %v, want nil", "z", got)
+	}
+}
+
+func TestPrefixMatchValues(t *testing.T) {
+	trie := NewRuneTrie()
+	trie.Put("ab", 1)
+	trie.Put("abc", 2)
+
+	got := trie.PrefixMatchValues("ab")
+	sort.Slice(got, func(i, j int) bool { return got[i].(int) < got[j].(int) })
+	want := []interface{}{1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PrefixMatchValues(%q) = %v, want %v", "ab", got, want)
+	}
+}
+
+func TestClosestKeys(t *testing.T) {
+	trie := buildTestTrie("cat", "car", "cart", "dog")
+
+	got := trie.ClosestKeys("cat", 1)
+	want := []string{"cat", "car", "cart"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClosestKeys(%q, 1) = %v, want %v", "cat", got, want)
+	}
+}
+
+func TestClosestKeysRootValue(t *testing.T) {
+	trie := NewRuneTrie()
+	trie.Put("", "rootval")
+
+	got := trie.ClosestKeys("", 0)
+	want := []string{""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClosestKeys(\"\", 0) = %v, want %v", got, want)
+	}
+}
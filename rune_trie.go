@@ -7,6 +7,18 @@ package trie
 type RuneTrie struct {
 	Value    interface{}
 	Children map[rune]*RuneTrie
+
+	// Resolver supplies subtrees missing from Children, letting the trie be
+	// backed by an external store. It is consulted only by TryGet, TryDelete
+	// and TryWalkPath; nil by default, in which case those report a missing
+	// child as a *MissingNodeError. Get/Delete/WalkPath never consult it.
+	Resolver NodeResolver
+
+	// Codec selects the ValueCodec used by MarshalBinary, UnmarshalBinary,
+	// WriteTo and ReadFrom on this trie. It is nil by default, which falls
+	// back to DefaultValueCodec; set it per-trie rather than overwriting
+	// DefaultValueCodec so that tries using different codecs don't race.
+	Codec ValueCodec
 }
 
 // NewRuneTrie allocates and returns a new *RuneTrie.
@@ -89,6 +101,11 @@ func (trie *RuneTrie) Delete(key string) bool {
 	return true // node (internal or not) existed and its Value was nil'd
 }
 
+// WalkFunc is the type of the function called for each key/Value visited by
+// Walk and WalkPath. If the walker function returns an error, the walk is
+// aborted and that error is returned to the caller of Walk/WalkPath.
+type WalkFunc func(key string, value interface{}) error
+
 // Walk iterates over each key/Value stored in the trie and calls the given
 // walker function with the key and Value. If the walker function returns
 // an error, the walk is aborted.
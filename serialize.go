@@ -0,0 +1,288 @@
+package trie
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// ValueCodec encodes and decodes the interface{} Values stored in a
+// RuneTrie so they can round-trip through MarshalBinary/UnmarshalBinary and
+// WriteTo/ReadFrom. Callers with a known concrete Value type can supply
+// their own codec; GobValueCodec and JSONValueCodec cover the common cases.
+type ValueCodec interface {
+	EncodeValue(value interface{}) ([]byte, error)
+	DecodeValue(data []byte) (interface{}, error)
+}
+
+// GobValueCodec encodes Values with encoding/gob. Concrete Value types must
+// be registered with gob.Register if they are not one of gob's builtin
+// types.
+type GobValueCodec struct{}
+
+// EncodeValue gob-encodes value.
+func (GobValueCodec) EncodeValue(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeValue gob-decodes data into an interface{}.
+func (GobValueCodec) DecodeValue(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// JSONValueCodec encodes Values with encoding/json. Since JSON does not
+// preserve Go's concrete type information, DecodeValue returns Values using
+// encoding/json's default decoding (float64, string, []interface{}, map,
+// bool, nil).
+type JSONValueCodec struct{}
+
+// EncodeValue JSON-encodes value.
+func (JSONValueCodec) EncodeValue(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// DecodeValue JSON-decodes data into an interface{}.
+func (JSONValueCodec) DecodeValue(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// DefaultValueCodec is the ValueCodec used by MarshalBinary, UnmarshalBinary,
+// WriteTo and ReadFrom when a trie's Codec field is nil. Callers that need a
+// different codec for a given trie should set that trie's Codec field
+// instead of overwriting DefaultValueCodec, which is shared by every trie in
+// the process and isn't synchronized.
+var DefaultValueCodec ValueCodec = GobValueCodec{}
+
+// codec returns trie.Codec, falling back to DefaultValueCodec if unset.
+func (trie *RuneTrie) codec() ValueCodec {
+	if trie.Codec != nil {
+		return trie.Codec
+	}
+	return DefaultValueCodec
+}
+
+const (
+	tagNoValue byte = 0
+	tagValue   byte = 1
+)
+
+// MarshalBinary encodes the trie using its Codec (or DefaultValueCodec if
+// unset). It implements encoding.BinaryMarshaler.
+func (trie *RuneTrie) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := trie.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a trie encoded by MarshalBinary using the
+// receiver's Codec (or DefaultValueCodec if unset), replacing the
+// receiver's contents. It implements encoding.BinaryUnmarshaler.
+func (trie *RuneTrie) UnmarshalBinary(data []byte) error {
+	_, err := trie.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteTo streams the trie to w in a compact, self-describing format: nodes
+// are emitted depth-first, each as a type tag (whether a Value is present),
+// the Value if any (length-prefixed and encoded with trie's Codec, or
+// DefaultValueCodec if unset), a varint child count, and for each child its
+// rune key followed by the child node itself.
+func (trie *RuneTrie) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := trie.writeNode(cw, trie.codec())
+	return cw.n, err
+}
+
+func (trie *RuneTrie) writeNode(w io.Writer, codec ValueCodec) error {
+	if trie.Value == nil {
+		if _, err := w.Write([]byte{tagNoValue}); err != nil {
+			return err
+		}
+	} else {
+		encoded, err := codec.EncodeValue(trie.Value)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte{tagValue}); err != nil {
+			return err
+		}
+		if err := writeUvarint(w, uint64(len(encoded))); err != nil {
+			return err
+		}
+		if _, err := w.Write(encoded); err != nil {
+			return err
+		}
+	}
+
+	if err := writeUvarint(w, uint64(len(trie.Children))); err != nil {
+		return err
+	}
+	for r, child := range trie.Children {
+		if err := writeUvarint(w, uint64(r)); err != nil {
+			return err
+		}
+		if err := child.writeNode(w, codec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFrom reads a trie encoded by WriteTo from r, using trie's Codec (or
+// DefaultValueCodec if unset), replacing the receiver's contents. ReadFrom
+// reads exactly the bytes that make up the encoded trie and no more, so r
+// can be a connection or stream shared with other data that follows.
+func (trie *RuneTrie) ReadFrom(r io.Reader) (int64, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+	cr := &countingByteReader{r: br}
+	err := trie.readNode(cr, trie.codec())
+	return cr.n, err
+}
+
+func (trie *RuneTrie) readNode(r io.ByteReader, codec ValueCodec) error {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if tag == tagValue {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		encoded := make([]byte, size)
+		for i := range encoded {
+			b, err := r.ReadByte()
+			if err != nil {
+				return err
+			}
+			encoded[i] = b
+		}
+		value, err := codec.DecodeValue(encoded)
+		if err != nil {
+			return err
+		}
+		trie.Value = value
+	} else {
+		trie.Value = nil
+	}
+
+	numChildren, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	if numChildren == 0 {
+		trie.Children = nil
+		return nil
+	}
+	trie.Children = make(map[rune]*RuneTrie, numChildren)
+	for i := uint64(0); i < numChildren; i++ {
+		rv, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		child := new(RuneTrie)
+		if err := child.readNode(r, codec); err != nil {
+			return err
+		}
+		trie.Children[rune(rv)] = child
+	}
+	return nil
+}
+
+// SaveToFile writes the trie to the named file using WriteTo, creating or
+// truncating it as needed.
+func (trie *RuneTrie) SaveToFile(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+	if _, err := trie.WriteTo(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// LoadFromFile reads a trie from the named file using ReadFrom, replacing
+// the receiver's contents.
+func (trie *RuneTrie) LoadFromFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = trie.ReadFrom(bufio.NewReader(f))
+	return err
+}
+
+func writeUvarint(w io.Writer, v uint64) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	_, err := w.Write(buf[:n])
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// byteReader adapts an io.Reader that isn't already an io.ByteReader,
+// reading exactly one byte at a time so it never pulls more off the
+// underlying stream than readNode actually consumes (unlike wrapping it in
+// a bufio.Reader, which prefetches and would swallow whatever follows the
+// encoded trie).
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (br *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(br.r, br.buf[:]); err != nil {
+		return 0, err
+	}
+	return br.buf[0], nil
+}
+
+type countingByteReader struct {
+	r io.ByteReader
+	n int64
+}
+
+func (cr *countingByteReader) ReadByte() (byte, error) {
+	b, err := cr.r.ReadByte()
+	if err == nil {
+		cr.n++
+	}
+	return b, err
+}
@@ -0,0 +1,156 @@
+package trie
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMarshalUnmarshalBinary(t *testing.T) {
+	trie := buildTestTrie("a", "ab", "abc", "b")
+	trie.Put("", "root")
+
+	data, err := trie.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	got := NewRuneTrie()
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+
+	for _, key := range []string{"", "a", "ab", "abc", "b"} {
+		want := trie.Get(key)
+		if g := got.Get(key); g != want {
+			t.Errorf("round-tripped Get(%q) = %v, want %v", key, g, want)
+		}
+	}
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	trie := buildTestTrie("x", "xy", "z")
+
+	var buf bytes.Buffer
+	if _, err := trie.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+
+	got := NewRuneTrie()
+	if _, err := got.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+
+	for _, key := range []string{"x", "xy", "z"} {
+		if g, want := got.Get(key), trie.Get(key); g != want {
+			t.Errorf("round-tripped Get(%q) = %v, want %v", key, g, want)
+		}
+	}
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	trie := buildTestTrie("cmd", "cmdline")
+	path := filepath.Join(t.TempDir(), "trie.bin")
+
+	if err := trie.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile() error = %v", err)
+	}
+
+	got := NewRuneTrie()
+	if err := got.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile() error = %v", err)
+	}
+
+	if g, want := got.Get("cmdline"), trie.Get("cmdline"); g != want {
+		t.Errorf("LoadFromFile round-trip Get(%q) = %v, want %v", "cmdline", g, want)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("SaveToFile did not create %s: %v", path, err)
+	}
+}
+
+// plainReader hides any io.ByteReader the underlying reader implements,
+// simulating a reader like net.Conn that only offers Read.
+type plainReader struct {
+	r io.Reader
+}
+
+func (p *plainReader) Read(b []byte) (int, error) {
+	return p.r.Read(b)
+}
+
+func TestReadFromDoesNotOverread(t *testing.T) {
+	trie := buildTestTrie("x", "xy")
+
+	var buf bytes.Buffer
+	if _, err := trie.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	trieLen := buf.Len()
+
+	trailer := []byte("next frame")
+	buf.Write(trailer)
+
+	got := NewRuneTrie()
+	n, err := got.ReadFrom(&plainReader{r: &buf})
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if n != int64(trieLen) {
+		t.Errorf("ReadFrom() consumed %d bytes, want %d", n, trieLen)
+	}
+	if remaining := buf.Bytes(); !bytes.Equal(remaining, trailer) {
+		t.Errorf("ReadFrom() over-read into the trailing frame: remaining = %q, want %q", remaining, trailer)
+	}
+}
+
+func TestPerTrieCodec(t *testing.T) {
+	trie := buildTestTrie("a")
+	trie.Codec = JSONValueCodec{}
+
+	data, err := trie.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	got := NewRuneTrie()
+	got.Codec = JSONValueCodec{}
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if g, want := got.Get("a"), trie.Get("a"); g != want {
+		t.Errorf("round-tripped Get(%q) = %v, want %v", "a", g, want)
+	}
+
+	// A trie using the default codec must be unaffected by another trie
+	// using a different one.
+	other := buildTestTrie("b")
+	otherData, err := other.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	otherGot := NewRuneTrie()
+	if err := otherGot.UnmarshalBinary(otherData); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	if g, want := otherGot.Get("b"), other.Get("b"); g != want {
+		t.Errorf("default-codec round-trip Get(%q) = %v, want %v", "b", g, want)
+	}
+}
+
+func TestJSONValueCodec(t *testing.T) {
+	codec := JSONValueCodec{}
+	encoded, err := codec.EncodeValue("hello")
+	if err != nil {
+		t.Fatalf("EncodeValue() error = %v", err)
+	}
+	decoded, err := codec.DecodeValue(encoded)
+	if err != nil {
+		t.Fatalf("DecodeValue() error = %v", err)
+	}
+	if decoded != "hello" {
+		t.Errorf("DecodeValue(EncodeValue(%q)) = %v, want %q", "hello", decoded, "hello")
+	}
+}
@@ -0,0 +1,177 @@
+package trie
+
+import (
+	"reflect"
+	"sync"
+)
+
+// SyncRuneTrie is a concurrent-safe wrapper around RuneTrie. Put and Delete
+// mutate via copy-on-write, copying only the spine of nodes from the root to
+// the modified leaf and sharing the rest. A Snapshot taken before a write
+// therefore keeps seeing the trie as it was, and can be walked or ranged
+// over safely while the writer keeps going.
+type SyncRuneTrie struct {
+	mu   sync.Mutex
+	root *RuneTrie
+}
+
+// NewSyncRuneTrie allocates and returns a new *SyncRuneTrie.
+func NewSyncRuneTrie() *SyncRuneTrie {
+	return &SyncRuneTrie{root: NewRuneTrie()}
+}
+
+// Snapshot returns an immutable, point-in-time view of the trie. The
+// returned *RuneTrie must not be mutated; subsequent Put/Delete calls on the
+// SyncRuneTrie never modify nodes reachable from a previously taken
+// Snapshot.
+func (trie *SyncRuneTrie) Snapshot() *RuneTrie {
+	trie.mu.Lock()
+	defer trie.mu.Unlock()
+	return trie.root
+}
+
+// Get returns the Value stored at the given key.
+func (trie *SyncRuneTrie) Get(key string) interface{} {
+	trie.mu.Lock()
+	defer trie.mu.Unlock()
+	return trie.root.Get(key)
+}
+
+// Put inserts the Value into the trie at the given key, replacing any
+// existing Value. It returns true if the put adds a new Value, false if it
+// replaces an existing Value. Put copies the spine of nodes from the root to
+// key's node so that earlier Snapshots are unaffected.
+func (trie *SyncRuneTrie) Put(key string, value interface{}) bool {
+	trie.mu.Lock()
+	defer trie.mu.Unlock()
+
+	newRoot := trie.root.clone()
+	node := newRoot
+	for _, r := range key {
+		child := node.Children[r]
+		if child == nil {
+			child = new(RuneTrie)
+		} else {
+			child = child.clone()
+		}
+		if node.Children == nil {
+			node.Children = map[rune]*RuneTrie{}
+		} else {
+			node.Children = cloneChildren(node.Children)
+		}
+		node.Children[r] = child
+		node = child
+	}
+	isNewVal := node.Value == nil
+	node.Value = value
+	trie.root = newRoot
+	return isNewVal
+}
+
+// Delete removes the Value associated with the given key, copying the spine
+// of affected nodes so that earlier Snapshots are unaffected. It returns
+// true if a node was found for the given key.
+func (trie *SyncRuneTrie) Delete(key string) bool {
+	trie.mu.Lock()
+	defer trie.mu.Unlock()
+
+	newRoot := trie.root.clone()
+	path := make([]nodeRune, len(key))
+	node := newRoot
+	for i, r := range key {
+		child := node.Children[r]
+		if child == nil {
+			return false
+		}
+		child = child.clone()
+		node.Children = cloneChildren(node.Children)
+		node.Children[r] = child
+		path[i] = nodeRune{r: r, node: node}
+		node = child
+	}
+
+	node.Value = nil
+	if node.isLeaf() {
+		for i := len(key) - 1; i >= 0; i-- {
+			parent := path[i].node
+			r := path[i].r
+			delete(parent.Children, r)
+			if !parent.isLeaf() {
+				break
+			}
+			parent.Children = nil
+			if parent.Value != nil {
+				break
+			}
+		}
+	}
+	trie.root = newRoot
+	return true
+}
+
+// clone returns a shallow copy of trie: a new node with the same Value and a
+// Children map that still points at the original (unmodified) subtrees. The
+// caller replaces individual Children entries before they are mutated.
+func (trie *RuneTrie) clone() *RuneTrie {
+	return &RuneTrie{Value: trie.Value, Children: trie.Children}
+}
+
+func cloneChildren(children map[rune]*RuneTrie) map[rune]*RuneTrie {
+	clone := make(map[rune]*RuneTrie, len(children))
+	for r, child := range children {
+		clone[r] = child
+	}
+	return clone
+}
+
+// Diff walks two snapshots in lockstep and reports the keys that were
+// added, removed, or whose Value changed between old and the receiver.
+// Shared subtrees (identified by pointer equality, which copy-on-write
+// guarantees for anything untouched between the two snapshots) are skipped
+// without being walked.
+func (trie *RuneTrie) Diff(old *RuneTrie) (added, removed, changed []string) {
+	diffNodes("", trie, old, &added, &removed, &changed)
+	return
+}
+
+func diffNodes(key string, next, prev *RuneTrie, added, removed, changed *[]string) {
+	if next == prev {
+		return
+	}
+	if next == nil {
+		prev.walk(key, func(k string, v interface{}) error {
+			*removed = append(*removed, k)
+			return nil
+		})
+		return
+	}
+	if prev == nil {
+		next.walk(key, func(k string, v interface{}) error {
+			*added = append(*added, k)
+			return nil
+		})
+		return
+	}
+
+	if next.Value != nil {
+		switch {
+		case prev.Value == nil:
+			*added = append(*added, key)
+		case !reflect.DeepEqual(next.Value, prev.Value):
+			*changed = append(*changed, key)
+		}
+	} else if prev.Value != nil {
+		*removed = append(*removed, key)
+	}
+
+	seen := make(map[rune]bool, len(next.Children))
+	for r, nextChild := range next.Children {
+		seen[r] = true
+		diffNodes(key+string(r), nextChild, prev.Children[r], added, removed, changed)
+	}
+	for r, prevChild := range prev.Children {
+		if !seen[r] {
+			diffNodes(key+string(r), nil, prevChild, added, removed, changed)
+		}
+	}
+}
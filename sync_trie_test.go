@@ -0,0 +1,94 @@
+package trie
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSyncRuneTriePutGet(t *testing.T) {
+	trie := NewSyncRuneTrie()
+	trie.Put("a", 1)
+	trie.Put("ab", 2)
+
+	if got := trie.Get("a"); got != 1 {
+		t.Errorf("Get(%q) = %v, want 1", "a", got)
+	}
+	if got := trie.Get("ab"); got != 2 {
+		t.Errorf("Get(%q) = %v, want 2", "ab", got)
+	}
+}
+
+func TestSyncRuneTrieSnapshotIsolation(t *testing.T) {
+	trie := NewSyncRuneTrie()
+	trie.Put("a", 1)
+
+	snap := trie.Snapshot()
+	trie.Put("a", 2)
+	trie.Put("b", 3)
+
+	if got := snap.Get("a"); got != 1 {
+		t.Errorf("snapshot Get(%q) = %v, want 1 (snapshot must not see later writes)", "a", got)
+	}
+	if got := snap.Get("b"); got != nil {
+		t.Errorf("snapshot Get(%q) = %v, want nil", "b", got)
+	}
+	if got := trie.Get("a"); got != 2 {
+		t.Errorf("writer Get(%q) = %v, want 2", "a", got)
+	}
+}
+
+func TestSyncRuneTrieDelete(t *testing.T) {
+	trie := NewSyncRuneTrie()
+	trie.Put("a", 1)
+
+	snap := trie.Snapshot()
+	if !trie.Delete("a") {
+		t.Fatal("Delete(\"a\") = false, want true")
+	}
+
+	if got := snap.Get("a"); got != 1 {
+		t.Errorf("snapshot Get(%q) after Delete = %v, want 1", "a", got)
+	}
+	if got := trie.Get("a"); got != nil {
+		t.Errorf("writer Get(%q) after Delete = %v, want nil", "a", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	trie := NewSyncRuneTrie()
+	trie.Put("a", 1)
+	trie.Put("b", 2)
+	old := trie.Snapshot()
+
+	trie.Put("a", 10) // changed
+	trie.Put("c", 3)  // added
+	trie.Delete("b")  // removed
+	next := trie.Snapshot()
+
+	added, removed, changed := next.Diff(old)
+	if !reflect.DeepEqual(added, []string{"c"}) {
+		t.Errorf("Diff added = %v, want [c]", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"b"}) {
+		t.Errorf("Diff removed = %v, want [b]", removed)
+	}
+	if !reflect.DeepEqual(changed, []string{"a"}) {
+		t.Errorf("Diff changed = %v, want [a]", changed)
+	}
+}
+
+// TestDiffUncomparableValue guards against a panic when Values are slices,
+// maps or funcs, which interface{} equality (==) cannot compare.
+func TestDiffUncomparableValue(t *testing.T) {
+	trie := NewSyncRuneTrie()
+	trie.Put("a", []int{1, 2})
+	old := trie.Snapshot()
+
+	trie.Put("a", []int{1, 2, 3})
+	next := trie.Snapshot()
+
+	_, _, changed := next.Diff(old)
+	if !reflect.DeepEqual(changed, []string{"a"}) {
+		t.Errorf("Diff changed = %v, want [a]", changed)
+	}
+}